@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// ArchSpec declares which cgroup subsystems are known to work on a given
+// architecture. A nil CgroupSubsystems means "no restriction beyond what
+// the rest of the engine already requires".
+type ArchSpec struct {
+	CgroupSubsystems []string
+}
+
+var (
+	archProbes = make(map[string]func() error)
+	archSpecs  = make(map[string]ArchSpec)
+)
+
+// RegisterArch registers probe as the capability check run for the named
+// GOARCH value. It is meant to be called from that architecture's
+// arch_<GOARCH>.go build-tagged file, in init().
+func RegisterArch(name string, probe func() error) {
+	archProbes[name] = probe
+}
+
+// RegisterArchSupport declares the ArchSpec for the named architecture.
+func RegisterArchSupport(name string, spec ArchSpec) {
+	archSpecs[name] = spec
+}
+
+// checkArch runs the probe registered for the running GOARCH, replacing
+// the previous hard-coded runtime.GOARCH != "amd64" gate in New.
+func checkArch() error {
+	probe, exists := archProbes[runtime.GOARCH]
+	if !exists {
+		return fmt.Errorf("The docker runtime does not support the %s architecture. Aborting.", runtime.GOARCH)
+	}
+	return probe()
+}
+
+// IsCgroupSubsystemSupported reports whether the named cgroup subsystem
+// (e.g. "blkio") is known to work on the running architecture, so callers
+// like fs.Apply can skip it instead of failing the whole container start.
+// Architectures that never called RegisterArchSupport are assumed to
+// support every subsystem.
+func IsCgroupSubsystemSupported(name string) bool {
+	spec, exists := archSpecs[runtime.GOARCH]
+	if !exists || spec.CgroupSubsystems == nil {
+		return true
+	}
+	for _, s := range spec.CgroupSubsystems {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+// +build amd64
+
+package engine
+
+func init() {
+	RegisterArch("amd64", func() error {
+		// amd64 is docker's reference architecture: every required
+		// feature has always been present, so there is nothing further
+		// to probe for.
+		return nil
+	})
+	// nil CgroupSubsystems means every subsystem is expected to work; amd64
+	// has no restrictions to declare.
+	RegisterArchSupport("amd64", ArchSpec{})
+}
@@ -0,0 +1,145 @@
+// Package capabilities provides cached runtime probes for the kernel
+// features the docker engine depends on. It exists so engine.New can
+// refuse to register handlers that need a specific feature instead of
+// gating everything on a hard-coded kernel version string, which is wrong
+// both ways: custom/backported kernels can have a feature despite an old
+// version string, and newer kernels can lack one despite a recent version.
+package capabilities
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Capabilities is the set of optional kernel features probed for at
+// engine startup.
+type Capabilities struct {
+	MemoryCgroup   bool
+	SwapAccounting bool
+	CFSBandwidth   bool
+	OverlayFS      bool
+	UserNamespaces bool
+	NetlinkVETH    bool
+}
+
+// Probe runs every capability check and returns the populated
+// Capabilities struct. Each underlying check is cached, so calling Probe
+// more than once is cheap.
+func Probe() Capabilities {
+	return Capabilities{
+		MemoryCgroup:   HasMemoryCgroup(),
+		SwapAccounting: HasSwapAccounting(),
+		CFSBandwidth:   HasCFSBandwidth(),
+		OverlayFS:      HasOverlayFS(),
+		UserNamespaces: HasUserNamespaces(),
+		NetlinkVETH:    HasNetlinkVETH(),
+	}
+}
+
+// probe caches the result of a single capability detector, since the
+// underlying checks are called once per handler registration but the
+// answer can't change over the life of the process.
+type probe struct {
+	once sync.Once
+	has  bool
+	fn   func() bool
+}
+
+func (p *probe) Has() bool {
+	p.once.Do(func() { p.has = p.fn() })
+	return p.has
+}
+
+var (
+	memoryCgroup   = &probe{fn: func() bool { return dirExists("/sys/fs/cgroup/memory") }}
+	swapAccounting = &probe{fn: func() bool { return fileExists("/sys/fs/cgroup/memory/memory.memsw.limit_in_bytes") }}
+	cfsBandwidth   = &probe{fn: func() bool { return fileExists("/sys/fs/cgroup/cpu/cpu.cfs_quota_us") }}
+	overlayFS      = &probe{fn: func() bool { return fsRegistered("overlay") || fsRegistered("overlayfs") }}
+	userNamespaces = &probe{fn: func() bool { return fileExists("/proc/self/ns/user") }}
+	netlinkVETH    = &probe{fn: checkNetlinkVETH}
+)
+
+// HasMemoryCgroup reports whether the kernel exposes the memory cgroup
+// controller.
+func HasMemoryCgroup() bool { return memoryCgroup.Has() }
+
+// HasSwapAccounting reports whether the memory cgroup controller was
+// built with swap accounting (CONFIG_MEMCG_SWAP_ENABLED, or booted with
+// swapaccount=1).
+func HasSwapAccounting() bool { return swapAccounting.Has() }
+
+// HasCFSBandwidth reports whether the cpu cgroup controller supports CFS
+// bandwidth control (CONFIG_CFS_BANDWIDTH).
+func HasCFSBandwidth() bool { return cfsBandwidth.Has() }
+
+// HasOverlayFS reports whether the running kernel has overlay (or the
+// older overlayfs) registered as a filesystem.
+func HasOverlayFS() bool { return overlayFS.Has() }
+
+// HasUserNamespaces reports whether the kernel supports user namespaces.
+func HasUserNamespaces() bool { return userNamespaces.Has() }
+
+// HasNetlinkVETH reports whether the kernel can create veth pairs over
+// netlink, which the bridge networking driver depends on.
+func HasNetlinkVETH() bool { return netlinkVETH.Has() }
+
+func dirExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func fsRegistered(name string) bool {
+	data, err := ioutil.ReadFile("/proc/filesystems")
+	if err != nil {
+		return false
+	}
+	return fsRegisteredInList(string(data), name)
+}
+
+func fsRegisteredInList(filesystems, name string) bool {
+	for _, line := range strings.Split(filesystems, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[len(fields)-1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNetlinkVETH reports whether the veth driver is available, either
+// loaded as a module or built directly into the kernel. /sys/class/net
+// always exists on a networked host regardless of veth support, so it
+// isn't a usable signal here.
+func checkNetlinkVETH() bool {
+	if moduleLoaded("veth") {
+		return true
+	}
+	// CONFIG_VETH=y (built-in, not a module) still registers under
+	// /sys/module even though it never appears in /proc/modules.
+	return dirExists("/sys/module/veth")
+}
+
+func moduleLoaded(name string) bool {
+	data, err := ioutil.ReadFile("/proc/modules")
+	if err != nil {
+		return false
+	}
+	return moduleLoadedInList(string(data), name)
+}
+
+func moduleLoadedInList(modules, name string) bool {
+	for _, line := range strings.Split(modules, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package capabilities
+
+import "testing"
+
+func TestFsRegisteredInList(t *testing.T) {
+	const filesystems = "nodev\tsysfs\n" +
+		"nodev\ttmpfs\n" +
+		"\text4\n" +
+		"nodev\toverlay\n"
+
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"overlay", true},
+		{"ext4", true},
+		{"overlayfs", false},
+		{"btrfs", false},
+	}
+	for _, c := range cases {
+		if got := fsRegisteredInList(filesystems, c.name); got != c.want {
+			t.Errorf("fsRegisteredInList(%q) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestModuleLoadedInList(t *testing.T) {
+	const modules = "veth 16384 0 - Live 0x0000000000000000\n" +
+		"bridge 155648 1 br_netfilter, Live 0x0000000000000000\n"
+
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"veth", true},
+		{"bridge", true},
+		{"br_netfilter", false},
+		{"dummy", false},
+	}
+	for _, c := range cases {
+		if got := moduleLoadedInList(modules, c.name); got != c.want {
+			t.Errorf("moduleLoadedInList(%q) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -2,19 +2,23 @@ package engine
 
 import (
 	"fmt"
+	"github.com/dotcloud/docker/engine/capabilities"
 	"github.com/dotcloud/docker/utils"
 	"log"
 	"os"
-	"runtime"
 	"strings"
 )
 
 type Handler func(*Job) Status
 
-var globalHandlers map[string]Handler
+var (
+	globalHandlers       map[string]Handler
+	requiredCapabilities map[string]func(capabilities.Capabilities) bool
+)
 
 func init() {
 	globalHandlers = make(map[string]Handler)
+	requiredCapabilities = make(map[string]func(capabilities.Capabilities) bool)
 }
 
 func Register(name string, handler Handler) error {
@@ -26,6 +30,15 @@ func Register(name string, handler Handler) error {
 	return nil
 }
 
+// RegisterRequiresCapability records that New must not copy the global
+// handler name onto an Engine unless requires reports true for that
+// engine's probed Capabilities; Engine.Register does not consult it. It's
+// meant to be called alongside Register, from the same init(), by handlers
+// that depend on a specific kernel feature.
+func RegisterRequiresCapability(name string, requires func(capabilities.Capabilities) bool) {
+	requiredCapabilities[name] = requires
+}
+
 // The Engine is the core of Docker.
 // It acts as a store for *containers*, and allows manipulation of these
 // containers by executing *jobs*.
@@ -34,12 +47,20 @@ type Engine struct {
 	handlers map[string]Handler
 	hack     Hack // data for temporary hackery (see hack.go)
 	id       string
+	caps     capabilities.Capabilities
 }
 
 func (eng *Engine) Root() string {
 	return eng.root
 }
 
+// Capabilities returns the kernel feature probes collected when the
+// engine was created. Handlers should consult this instead of inferring
+// feature support from a kernel version string.
+func (eng *Engine) Capabilities() capabilities.Capabilities {
+	return eng.caps
+}
+
 func (eng *Engine) Register(name string, handler Handler) error {
 	eng.Logf("Register(%s) (handlers=%v)", name, eng.handlers)
 	_, exists := eng.handlers[name]
@@ -56,25 +77,17 @@ func (eng *Engine) Register(name string, handler Handler) error {
 // behavior.
 func New(root string) (*Engine, error) {
 	// Check for unsupported architectures
-	if runtime.GOARCH != "amd64" {
-		return nil, fmt.Errorf("The docker runtime currently only supports amd64 (not %s). This will change in the future. Aborting.", runtime.GOARCH)
-	}
-	// Check for unsupported kernel versions
-	// FIXME: it would be cleaner to not test for specific versions, but rather
-	// test for specific functionalities.
-	// Unfortunately we can't test for the feature "does not cause a kernel panic"
-	// without actually causing a kernel panic, so we need this workaround until
-	// the circumstances of pre-3.8 crashes are clearer.
-	// For details see http://github.com/dotcloud/docker/issues/407
-	if k, err := utils.GetKernelVersion(); err != nil {
-		log.Printf("WARNING: %s\n", err)
-	} else {
-		if utils.CompareKernelVersion(k, &utils.KernelVersionInfo{Kernel: 3, Major: 8, Minor: 0}) < 0 {
-			if os.Getenv("DOCKER_NOWARN_KERNEL_VERSION") == "" {
-				log.Printf("WARNING: You are running linux kernel version %s, which might be unstable running docker. Please upgrade your kernel to 3.8.0.", k.String())
-			}
-		}
+	if err := checkArch(); err != nil {
+		return nil, err
 	}
+	// Probe for the kernel features the engine and its handlers depend on,
+	// instead of testing for a specific kernel version. This lets docker run
+	// on non-standard kernels (custom builds, backports) that have the
+	// needed features even when the version string says otherwise, and
+	// correctly refuse operations on newer kernels that lack an expected
+	// feature.
+	caps := capabilities.Probe()
+	warnMissingCapabilities(caps)
 	if err := os.MkdirAll(root, 0700); err != nil && !os.IsExist(err) {
 		return nil, err
 	}
@@ -82,14 +95,49 @@ func New(root string) (*Engine, error) {
 		root:     root,
 		handlers: make(map[string]Handler),
 		id:       utils.RandomString(),
+		caps:     caps,
 	}
-	// Copy existing global handlers
+	// Copy existing global handlers, skipping any whose required
+	// capability this kernel lacks rather than registering a handler that
+	// would only fail when run.
 	for k, v := range globalHandlers {
+		if requires, exists := requiredCapabilities[k]; exists && !requires(caps) {
+			eng.Logf("skipping %s: required capability not available", k)
+			continue
+		}
 		eng.handlers[k] = v
 	}
 	return eng, nil
 }
 
+// warnMissingCapabilities logs a warning for each probed capability that
+// the running kernel lacks, unless silenced by its own
+// DOCKER_NOWARN_KERNEL_VERSION-style override (e.g.
+// DOCKER_NOWARN_CAP_MEMORY_CGROUP=1).
+func warnMissingCapabilities(caps capabilities.Capabilities) {
+	missing := []struct {
+		env string
+		has bool
+		msg string
+	}{
+		{"MEMORY_CGROUP", caps.MemoryCgroup, "the memory cgroup controller, needed to limit container memory"},
+		{"SWAP_ACCOUNTING", caps.SwapAccounting, "swap accounting, needed to limit container swap usage"},
+		{"CFS_BANDWIDTH", caps.CFSBandwidth, "CFS bandwidth control, needed for cpu quota limits"},
+		{"OVERLAYFS", caps.OverlayFS, "overlay filesystem support"},
+		{"USER_NAMESPACES", caps.UserNamespaces, "user namespaces"},
+		{"NETLINK_VETH", caps.NetlinkVETH, "veth pair creation over netlink, needed for container networking"},
+	}
+	for _, cap := range missing {
+		if cap.has {
+			continue
+		}
+		if os.Getenv("DOCKER_NOWARN_CAP_"+cap.env) != "" {
+			continue
+		}
+		log.Printf("WARNING: Your kernel does not support %s. Set DOCKER_NOWARN_CAP_%s=1 to silence this warning.", cap.msg, cap.env)
+	}
+}
+
 func (eng *Engine) String() string {
 	return fmt.Sprintf("%s|%s", eng.Root(), eng.id[:8])
 }
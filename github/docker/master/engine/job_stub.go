@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"io"
+	"strconv"
+)
+
+type Hack map[string]interface{}
+type Status int
+const (
+	StatusOK Status = 0
+	StatusErr Status = 1
+)
+
+type Output struct{ writers []io.WriteCloser }
+func NewOutput() *Output { return &Output{} }
+func (o *Output) Add(w io.WriteCloser) { o.writers = append(o.writers, w) }
+func (o *Output) Write(p []byte) (int, error) {
+	for _, w := range o.writers { w.Write(p) }
+	return len(p), nil
+}
+
+type Input struct{}
+func NewInput() *Input { return &Input{} }
+
+type Job struct {
+	Eng *Engine
+	Name string
+	Args []string
+	Stdin *Input
+	Stdout *Output
+	Stderr *Output
+	handler Handler
+	env map[string]string
+}
+func (j *Job) Errorf(format string, args ...interface{}) {}
+func (j *Job) Error(err error) {}
+func (j *Job) Getenv(key string) string { return j.env[key] }
+func (j *Job) GetenvInt(key string) int { v, _ := strconv.Atoi(j.env[key]); return v }
+func (j *Job) GetenvInt64(key string) int64 { v, _ := strconv.ParseInt(j.env[key], 10, 64); return v }
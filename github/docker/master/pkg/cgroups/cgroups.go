@@ -0,0 +1,119 @@
+package cgroups
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+)
+
+var ErrNotFound = errors.New("mountpoint not found")
+
+// Cgroup describes the resource limits and device access to apply to a
+// container. It is passed to the active driver's Apply (and GetStats) to
+// create (or inspect) the cgroup hierarchy for a process.
+type Cgroup struct {
+	Name   string `json:"name,omitempty"`
+	Parent string `json:"parent,omitempty"`
+
+	DeviceAccess bool   `json:"device_access,omitempty"`
+	Memory       int64  `json:"memory,omitempty"`
+	MemorySwap   int64  `json:"memory_swap,omitempty"`
+	CpuShares    int64  `json:"cpu_shares,omitempty"`
+	CpusetCpus   string `json:"cpuset_cpus,omitempty"`
+
+	// CFS bandwidth control; zero means "leave the kernel default alone".
+	CpuCfsPeriod int64 `json:"cpu_cfs_period,omitempty"`
+	CpuCfsQuota  int64 `json:"cpu_cfs_quota,omitempty"`
+
+	// Realtime scheduling; zero means "leave the kernel default alone".
+	CpuRtPeriod  int64 `json:"cpu_rt_period,omitempty"`
+	CpuRtRuntime int64 `json:"cpu_rt_runtime,omitempty"`
+
+	// MemorySwappiness is a percentage in [0, 100]; nil means "leave the
+	// kernel default alone", matching the zero-value-is-a-no-op convention
+	// the rest of this struct uses (a plain int64 can't tell "0" from
+	// "unset").
+	MemorySwappiness  *int64 `json:"memory_swappiness,omitempty"`
+	OomKillDisable    bool  `json:"oom_kill_disable,omitempty"`
+	KernelMemory      int64 `json:"kernel_memory,omitempty"`
+	MemoryReservation int64 `json:"memory_reservation,omitempty"`
+
+	BlkioWeight                  uint16             `json:"blkio_weight,omitempty"`
+	BlkioWeightDevice            []BlkioDeviceValue `json:"blkio_weight_device,omitempty"`
+	BlkioThrottleReadBpsDevice   []BlkioDeviceValue `json:"blkio_throttle_read_bps_device,omitempty"`
+	BlkioThrottleWriteBpsDevice  []BlkioDeviceValue `json:"blkio_throttle_write_bps_device,omitempty"`
+	BlkioThrottleReadIOPSDevice  []BlkioDeviceValue `json:"blkio_throttle_read_iops_device,omitempty"`
+	BlkioThrottleWriteIOPSDevice []BlkioDeviceValue `json:"blkio_throttle_write_iops_device,omitempty"`
+}
+
+// BlkioDeviceValue is one per-device setting for a blkio.* control file
+// that takes "<major>:<minor> <value>" lines, e.g. blkio.weight_device or
+// blkio.throttle.read_bps_device.
+type BlkioDeviceValue struct {
+	Major int64
+	Minor int64
+	Value uint64
+}
+
+// ActiveCgroup is returned by a driver's Apply and represents the set of
+// cgroups a running container was joined to.
+type ActiveCgroup interface {
+	// Cleanup removes (or, for drivers that don't own the hierarchy
+	// directly, stops) the cgroups that were created for the container.
+	Cleanup() error
+}
+
+// FindCgroupMountpoint returns the path at which the given subsystem is
+// mounted, by scanning /proc/self/mountinfo the same way the kernel's
+// findmnt does.
+func FindCgroupMountpoint(subsystem string) (string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := scanner.Text()
+		fields := strings.Split(text, " ")
+		for _, opt := range strings.Split(fields[len(fields)-1], ",") {
+			if opt == subsystem {
+				return fields[4], nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", ErrNotFound
+}
+
+// GetInitCgroupDir returns the cgroup path of the init process (pid 1) for
+// the given subsystem, so containers can be nested under it.
+func GetInitCgroupDir(subsystem string) (string, error) {
+	f, err := os.Open("/proc/1/cgroup")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := scanner.Text()
+		parts := strings.Split(text, ":")
+		if len(parts) != 3 {
+			continue
+		}
+		for _, sub := range strings.Split(parts[1], ",") {
+			if sub == subsystem {
+				return parts[2], nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "/", nil
+}
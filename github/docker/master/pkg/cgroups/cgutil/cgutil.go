@@ -0,0 +1,143 @@
+// Package cgutil implements cgroup introspection: given a container's
+// cgroup path, it walks every mounted subsystem and reads every readable
+// control file into a structured dump, so operators can diagnose "why is
+// this container slow" without shelling into /sys/fs/cgroup by hand.
+package cgutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// Dump is subsystem -> file -> parsed value for a single cgroup path.
+type Dump map[string]map[string]interface{}
+
+// Inspect walks every mounted subsystem under /sys/fs/cgroup, joins
+// cgroupPath under each the same way fs/apply_raw.go's data.path does (so
+// a docker install nested under another cgroup, e.g. systemd's
+// system.slice, is resolved consistently between container_stats and
+// cgroup_inspect), and reads every readable control file into a Dump.
+func Inspect(cgroupPath string) (Dump, error) {
+	mounts, err := mountedSubsystems()
+	if err != nil {
+		return nil, err
+	}
+
+	dump := make(Dump)
+	for subsystem, mountpoint := range mounts {
+		initPath, err := cgroups.GetInitCgroupDir(subsystem)
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(mountpoint, initPath, cgroupPath)
+		files, err := readableFiles(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		dump[subsystem] = files
+	}
+	return dump, nil
+}
+
+var knownSubsystems = map[string]bool{
+	"cpu": true, "cpuacct": true, "cpuset": true, "memory": true,
+	"blkio": true, "devices": true, "freezer": true, "perf_event": true,
+	"net_cls": true, "net_prio": true, "hugetlb": true, "pids": true,
+}
+
+// mountedSubsystems returns subsystem name -> mountpoint for every cgroup
+// subsystem mounted on the host, by scanning /proc/self/mountinfo the same
+// way cgroups.FindCgroupMountpoint does for a single subsystem.
+func mountedSubsystems() (map[string]string, error) {
+	data, err := ioutil.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	mounts := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Split(line, " ")
+		if len(fields) < 5 {
+			continue
+		}
+		mountpoint := fields[4]
+		for _, opt := range strings.Split(fields[len(fields)-1], ",") {
+			if knownSubsystems[opt] {
+				mounts[opt] = mountpoint
+			}
+		}
+	}
+	return mounts, nil
+}
+
+func readableFiles(dir string) (map[string]interface{}, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{})
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		contents, err := cgroups.ReadCgroupFile(dir, name)
+		if err != nil {
+			// not every control file is readable (e.g. write-only
+			// knobs); skip it rather than fail the whole dump.
+			continue
+		}
+		out[name] = parseValue(dir, name, contents)
+	}
+	return out, nil
+}
+
+// parseValue renders the contents of a single control file the way a
+// caller would expect to consume it: numeric arrays for *.usage_percpu,
+// key/value maps for memory.stat/cpu.stat, per-device entry slices for
+// blkio.*_recursive, int slices for tasks/cgroup.procs, and the trimmed
+// string (or number) otherwise. It
+// reuses the same parsers GetStats uses, so the two features agree on
+// what a control file means.
+func parseValue(dir, name, contents string) interface{} {
+	trimmed := strings.TrimSpace(contents)
+	switch {
+	case strings.HasSuffix(name, "usage_percpu"):
+		v, _ := cgroups.ParseUintArrayFile(dir, name)
+		return v
+	case name == "tasks" || name == "cgroup.procs":
+		return parseIntSlice(trimmed)
+	case strings.HasPrefix(name, "blkio.") && strings.HasSuffix(name, "_recursive"):
+		v, _ := cgroups.ParseBlkioFile(dir, name)
+		return v
+	case name == "memory.stat" || name == "cpu.stat":
+		v, _ := cgroups.ParseKeyValueFile(dir, name)
+		return v
+	default:
+		if v, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return v
+		}
+		return trimmed
+	}
+}
+
+func parseIntSlice(s string) []int {
+	var out []int
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if v, err := strconv.Atoi(line); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package cgutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+func TestParseValue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgutil-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "cpuacct.usage_percpu", "1 2 3\n")
+	if v, ok := parseValue(dir, "cpuacct.usage_percpu", "1 2 3\n").([]uint64); !ok || !reflect.DeepEqual(v, []uint64{1, 2, 3}) {
+		t.Errorf("parseValue(usage_percpu) = %#v, want [1 2 3]", v)
+	}
+
+	if v, ok := parseValue(dir, "tasks", "1\n2\n\n3\n").([]int); !ok || !reflect.DeepEqual(v, []int{1, 2, 3}) {
+		t.Errorf("parseValue(tasks) = %#v, want [1 2 3]", v)
+	}
+	if v, ok := parseValue(dir, "cgroup.procs", "4\n5\n").([]int); !ok || !reflect.DeepEqual(v, []int{4, 5}) {
+		t.Errorf("parseValue(cgroup.procs) = %#v, want [4 5]", v)
+	}
+
+	writeTestFile(t, dir, "blkio.io_service_bytes_recursive", "8:0 Read 1024\n")
+	v, ok := parseValue(dir, "blkio.io_service_bytes_recursive", "8:0 Read 1024\n").([]cgroups.BlkioStatEntry)
+	if !ok || len(v) != 1 || v[0] != (cgroups.BlkioStatEntry{Major: 8, Minor: 0, Op: "Read", Value: 1024}) {
+		t.Errorf("parseValue(blkio recursive) = %#v", v)
+	}
+
+	writeTestFile(t, dir, "memory.stat", "cache 100\nrss 200\n")
+	kv, ok := parseValue(dir, "memory.stat", "cache 100\nrss 200\n").(map[string]uint64)
+	if !ok || kv["cache"] != 100 || kv["rss"] != 200 {
+		t.Errorf("parseValue(memory.stat) = %#v", kv)
+	}
+
+	if v := parseValue(dir, "memory.limit_in_bytes", "12345\n"); v != int64(12345) {
+		t.Errorf("parseValue(numeric fallback) = %#v, want 12345", v)
+	}
+	if v := parseValue(dir, "cpuset.cpus", "0-3\n"); v != "0-3" {
+		t.Errorf("parseValue(string fallback) = %#v, want %q", v, "0-3")
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name, contents string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadableFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgutil-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "memory.limit_in_bytes", "1000\n")
+	writeTestFile(t, dir, "cpuset.cpus", "0-3\n")
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := readableFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("readableFiles() = %#v, want 2 entries", files)
+	}
+	if files["memory.limit_in_bytes"] != int64(1000) {
+		t.Errorf("readableFiles()[memory.limit_in_bytes] = %#v, want 1000", files["memory.limit_in_bytes"])
+	}
+	if files["cpuset.cpus"] != "0-3" {
+		t.Errorf("readableFiles()[cpuset.cpus] = %#v, want \"0-3\"", files["cpuset.cpus"])
+	}
+
+	if _, err := readableFiles(filepath.Join(dir, "missing")); err == nil {
+		t.Error("readableFiles(missing dir) = nil error, want error")
+	}
+}
+
+func TestCpuUsage(t *testing.T) {
+	d := Dump{"cpuacct": {"cpuacct.usage": int64(42)}}
+	v, ok := cpuUsage(d)
+	if !ok || v != 42 {
+		t.Errorf("cpuUsage() = %d, %v; want 42, true", v, ok)
+	}
+
+	if _, ok := cpuUsage(Dump{}); ok {
+		t.Error("cpuUsage(no cpuacct) = true, want false")
+	}
+	if _, ok := cpuUsage(Dump{"cpuacct": {"cpuacct.usage": "not-a-number"}}); ok {
+		t.Error("cpuUsage(wrong type) = true, want false")
+	}
+}
+
+func TestBlkioServiceBytesByDevice(t *testing.T) {
+	entries := []cgroups.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 100},
+		{Major: 8, Minor: 0, Op: "Total", Value: 300},
+		{Major: 8, Minor: 1, Op: "total", Value: 50},
+	}
+	d := Dump{"blkio": {"blkio.io_service_bytes_recursive": entries}}
+	got := blkioServiceBytesByDevice(d)
+	want := map[string]uint64{"8:0": 300, "8:1": 50}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("blkioServiceBytesByDevice() = %v, want %v", got, want)
+	}
+
+	if got := blkioServiceBytesByDevice(Dump{}); len(got) != 0 {
+		t.Errorf("blkioServiceBytesByDevice(no blkio) = %v, want empty", got)
+	}
+}
+
+func TestDeviceKey(t *testing.T) {
+	if got := deviceKey(cgroups.BlkioStatEntry{Major: 8, Minor: 16}); got != "8:16" {
+		t.Errorf("deviceKey() = %q, want %q", got, "8:16")
+	}
+}
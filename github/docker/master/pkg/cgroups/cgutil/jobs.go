@@ -0,0 +1,54 @@
+package cgutil
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dotcloud/docker/engine"
+)
+
+func init() {
+	engine.Register("cgroup_inspect", jobInspect)
+}
+
+// jobInspect walks the cgroup named by its single argument (a container
+// id or an arbitrary cgroup path) and writes a JSON dump of every
+// readable control file to stdout. Setting the "Interval" environment
+// variable (a duration string, e.g. "1s") switches to sample mode: two
+// snapshots that far apart, reduced to cpu/blkio deltas.
+func jobInspect(job *engine.Job) engine.Status {
+	if len(job.Args) != 1 {
+		job.Errorf("usage: %s CONTAINER|CGROUP_PATH", job.Name)
+		return engine.StatusErr
+	}
+	cgroupPath := job.Args[0]
+
+	if raw := job.Getenv("Interval"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil {
+			job.Error(err)
+			return engine.StatusErr
+		}
+		delta, err := Sample(cgroupPath, interval)
+		if err != nil {
+			job.Error(err)
+			return engine.StatusErr
+		}
+		if err := json.NewEncoder(job.Stdout).Encode(delta); err != nil {
+			job.Error(err)
+			return engine.StatusErr
+		}
+		return engine.StatusOK
+	}
+
+	dump, err := Inspect(cgroupPath)
+	if err != nil {
+		job.Error(err)
+		return engine.StatusErr
+	}
+	if err := json.NewEncoder(job.Stdout).Encode(dump); err != nil {
+		job.Error(err)
+		return engine.StatusErr
+	}
+	return engine.StatusOK
+}
@@ -0,0 +1,87 @@
+package cgutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// Delta is a two-snapshot comparison of a single cgroup: cpu usage in
+// nanoseconds/sec and blkio bytes/sec per device, computed over Interval.
+type Delta struct {
+	Interval                 time.Duration
+	CpuNanosPerSec           float64
+	BlkioBytesPerSecByDevice map[string]float64
+}
+
+// Sample takes two Inspect snapshots of cgroupPath, interval apart, and
+// computes the rates of change operators care about when diagnosing a
+// slow container, instead of having to do the subtraction by hand.
+func Sample(cgroupPath string, interval time.Duration) (*Delta, error) {
+	before, err := Inspect(cgroupPath)
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(interval)
+	after, err := Inspect(cgroupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := &Delta{
+		Interval:                 interval,
+		BlkioBytesPerSecByDevice: make(map[string]float64),
+	}
+
+	if b, ok := cpuUsage(before); ok {
+		if a, ok := cpuUsage(after); ok && a >= b {
+			delta.CpuNanosPerSec = float64(a-b) / interval.Seconds()
+		}
+	}
+
+	beforeIO := blkioServiceBytesByDevice(before)
+	for device, a := range blkioServiceBytesByDevice(after) {
+		if b, ok := beforeIO[device]; ok && a >= b {
+			delta.BlkioBytesPerSecByDevice[device] = float64(a-b) / interval.Seconds()
+		}
+	}
+
+	return delta, nil
+}
+
+func cpuUsage(d Dump) (uint64, bool) {
+	files, ok := d["cpuacct"]
+	if !ok {
+		return 0, false
+	}
+	v, ok := files["cpuacct.usage"].(int64)
+	if !ok {
+		return 0, false
+	}
+	return uint64(v), true
+}
+
+func blkioServiceBytesByDevice(d Dump) map[string]uint64 {
+	out := make(map[string]uint64)
+	files, ok := d["blkio"]
+	if !ok {
+		return out
+	}
+	entries, ok := files["blkio.io_service_bytes_recursive"].([]cgroups.BlkioStatEntry)
+	if !ok {
+		return out
+	}
+	for _, e := range entries {
+		if !strings.EqualFold(e.Op, "Total") {
+			continue
+		}
+		out[deviceKey(e)] += e.Value
+	}
+	return out
+}
+
+func deviceKey(e cgroups.BlkioStatEntry) string {
+	return fmt.Sprintf("%d:%d", e.Major, e.Minor)
+}
@@ -0,0 +1,48 @@
+package cgroups
+
+import "os"
+
+// driverEntry pairs a driver name with its Apply function. Drivers
+// register themselves from their own package's init(), the same pattern
+// engine.Register uses for job handlers, so that pkg/cgroups never has to
+// import pkg/cgroups/fs or pkg/cgroups/systemd directly.
+type driverEntry struct {
+	name  string
+	apply func(*Cgroup, int) (ActiveCgroup, error)
+}
+
+var drivers []driverEntry
+
+// RegisterDriver makes a cgroup backend available to NewDriver. It is
+// meant to be called from a driver package's init().
+func RegisterDriver(name string, apply func(*Cgroup, int) (ActiveCgroup, error)) {
+	drivers = append(drivers, driverEntry{name: name, apply: apply})
+}
+
+// NewDriver picks the systemd driver when systemd owns the cgroup
+// hierarchy (detected via the presence of /run/systemd/system), falling
+// back to the raw fs driver otherwise. If the preferred driver package was
+// never imported (and registered), it returns whichever driver was
+// registered first; it returns nil only when no driver is registered.
+func NewDriver() func(*Cgroup, int) (ActiveCgroup, error) {
+	want := "fs"
+	if useSystemd() {
+		want = "systemd"
+	}
+	for _, d := range drivers {
+		if d.name == want {
+			return d.apply
+		}
+	}
+	// Fall back to whatever was registered, so a binary that only links
+	// one driver package still works.
+	for _, d := range drivers {
+		return d.apply
+	}
+	return nil
+}
+
+func useSystemd() bool {
+	_, err := os.Stat("/run/systemd/system")
+	return err == nil
+}
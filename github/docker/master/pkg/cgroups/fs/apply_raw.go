@@ -7,24 +7,38 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/dotcloud/docker/engine"
 	"github.com/dotcloud/docker/pkg/cgroups"
 )
 
+func init() {
+	cgroups.RegisterDriver("fs", Apply)
+}
+
 var (
-	subsystems = []subsystem{
-		&devicesGroup{},
-		&memoryGroup{},
-		&cpuGroup{},
-		&cpusetGroup{},
-		&cpuacctGroup{},
-		&blkioGroup{},
-		&perfEventGroup{},
-		&freezerGroup{},
+	subsystems = []subsystemEntry{
+		{"devices", &devicesGroup{}},
+		{"memory", &memoryGroup{}},
+		{"cpu", &cpuGroup{}},
+		{"cpuset", &cpusetGroup{}},
+		{"cpuacct", &cpuacctGroup{}},
+		{"blkio", &blkioGroup{}},
+		{"perf_event", &perfEventGroup{}},
+		{"freezer", &freezerGroup{}},
 	}
 )
 
+// subsystemEntry pairs a subsystem implementation with the name of the
+// cgroup hierarchy it is mounted under, so Apply/Cleanup/GetStats can all
+// walk the same list instead of keeping their own.
+type subsystemEntry struct {
+	name string
+	sys  subsystem
+}
+
 type subsystem interface {
 	Set(*data) error
+	GetStats(path string, stats *cgroups.Stats) error
 }
 
 type data struct {
@@ -64,7 +78,13 @@ func Apply(c *cgroups.Cgroup, pid int) (cgroups.ActiveCgroup, error) {
 		pid:    pid,
 	}
 	for _, sys := range subsystems {
-		if err := sys.Set(d); err != nil {
+		// Some architectures' kernels don't wire up every subsystem (e.g.
+		// no blkio on some ARM kernels); skip those instead of failing
+		// the whole container start.
+		if !engine.IsCgroupSubsystemSupported(sys.name) {
+			continue
+		}
+		if err := sys.sys.Set(d); err != nil {
 			return nil, err
 		}
 	}
@@ -87,28 +107,20 @@ func (raw *data) join(subsystem string) (string, error) {
 	if err := os.MkdirAll(path, 0755); err != nil && !os.IsExist(err) {
 		return "", err
 	}
-	if err := writeFile(path, "cgroup.procs", strconv.Itoa(raw.pid)); err != nil {
-		return "", err
+	// A zero pid means "just touch the hierarchy and apply settings,
+	// don't join a process" -- used by SetResources to update the limits
+	// of an already-running container.
+	if raw.pid != 0 {
+		if err := writeFile(path, "cgroup.procs", strconv.Itoa(raw.pid)); err != nil {
+			return "", err
+		}
 	}
 	return path, nil
 }
 
 func (raw *data) Cleanup() error {
-	get := func(subsystem string) string {
-		path, _ := raw.path(subsystem)
-		return path
-	}
-
-	for _, path := range []string{
-		get("memory"),
-		get("devices"),
-		get("cpu"),
-		get("cpuset"),
-		get("cpuacct"),
-		get("blkio"),
-		get("perf_event"),
-		get("freezer"),
-	} {
+	for _, sys := range subsystems {
+		path, _ := raw.path(sys.name)
 		if path != "" {
 			os.RemoveAll(path)
 		}
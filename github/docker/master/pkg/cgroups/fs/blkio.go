@@ -0,0 +1,79 @@
+package fs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+type blkioGroup struct {
+}
+
+func (s *blkioGroup) Set(d *data) error {
+	dir, err := d.join("blkio")
+	if err != nil {
+		if err == cgroups.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if d.c.BlkioWeight != 0 {
+		if err := writeFile(dir, "blkio.weight", strconv.FormatUint(uint64(d.c.BlkioWeight), 10)); err != nil {
+			return err
+		}
+	}
+	if err := writeBlkioDeviceValues(dir, "blkio.weight_device", d.c.BlkioWeightDevice); err != nil {
+		return err
+	}
+	if err := writeBlkioDeviceValues(dir, "blkio.throttle.read_bps_device", d.c.BlkioThrottleReadBpsDevice); err != nil {
+		return err
+	}
+	if err := writeBlkioDeviceValues(dir, "blkio.throttle.write_bps_device", d.c.BlkioThrottleWriteBpsDevice); err != nil {
+		return err
+	}
+	if err := writeBlkioDeviceValues(dir, "blkio.throttle.read_iops_device", d.c.BlkioThrottleReadIOPSDevice); err != nil {
+		return err
+	}
+	if err := writeBlkioDeviceValues(dir, "blkio.throttle.write_iops_device", d.c.BlkioThrottleWriteIOPSDevice); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeBlkioDeviceValues writes one "<major>:<minor> <value>" line per
+// entry to file, which is the format every per-device blkio control file
+// shares (blkio.weight_device, blkio.throttle.*_device).
+func writeBlkioDeviceValues(dir, file string, entries []cgroups.BlkioDeviceValue) error {
+	for _, e := range entries {
+		line := fmt.Sprintf("%d:%d %d", e.Major, e.Minor, e.Value)
+		if err := writeFile(dir, file, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *blkioGroup) GetStats(path string, stats *cgroups.Stats) error {
+	var err error
+	if stats.BlkioStats.IoServiceBytesRecursive, err = parseBlkioFile(path, "blkio.io_service_bytes_recursive"); err != nil {
+		return err
+	}
+	if stats.BlkioStats.IoServicedRecursive, err = parseBlkioFile(path, "blkio.io_serviced_recursive"); err != nil {
+		return err
+	}
+	if stats.BlkioStats.IoQueuedRecursive, err = parseBlkioFile(path, "blkio.io_queued_recursive"); err != nil {
+		return err
+	}
+	if stats.BlkioStats.IoServiceTimeRecursive, err = parseBlkioFile(path, "blkio.io_service_time_recursive"); err != nil {
+		return err
+	}
+	if stats.BlkioStats.IoWaitTimeRecursive, err = parseBlkioFile(path, "blkio.io_wait_time_recursive"); err != nil {
+		return err
+	}
+	if stats.BlkioStats.IoMergedRecursive, err = parseBlkioFile(path, "blkio.io_merged_recursive"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package fs
 
 import (
 	"strconv"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
 )
 
 type cpuGroup struct {
@@ -19,5 +21,38 @@ func (s *cpuGroup) Set(d *data) error {
 			return err
 		}
 	}
+	if d.c.CpuCfsPeriod != 0 {
+		if err := writeFile(dir, "cpu.cfs_period_us", strconv.FormatInt(d.c.CpuCfsPeriod, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.CpuCfsQuota != 0 {
+		if err := writeFile(dir, "cpu.cfs_quota_us", strconv.FormatInt(d.c.CpuCfsQuota, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.CpuRtPeriod != 0 {
+		if err := writeFile(dir, "cpu.rt_period_us", strconv.FormatInt(d.c.CpuRtPeriod, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.CpuRtRuntime != 0 {
+		if err := writeFile(dir, "cpu.rt_runtime_us", strconv.FormatInt(d.c.CpuRtRuntime, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *cpuGroup) GetStats(path string, stats *cgroups.Stats) error {
+	f, err := getCgroupParamKeyValue(path, "cpu.stat")
+	if err != nil {
+		return err
+	}
+	stats.CpuStats.ThrottlingData = cgroups.ThrottlingData{
+		Periods:          f["nr_periods"],
+		ThrottledPeriods: f["nr_throttled"],
+		ThrottledTime:    f["throttled_time"],
+	}
 	return nil
 }
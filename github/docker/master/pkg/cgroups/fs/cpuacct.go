@@ -0,0 +1,47 @@
+package fs
+
+import (
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// clockTicksPerSecond is USER_HZ, the unit cpuacct.stat's user/system
+// fields are reported in. It's a compile-time kernel constant fixed at
+// 100 on every architecture Linux runs on, so it's safe to hard-code
+// rather than shell out to getconf(1).
+const clockTicksPerSecond = 100
+
+type cpuacctGroup struct {
+}
+
+func (s *cpuacctGroup) Set(d *data) error {
+	// we just want to join this group even though we don't set anything
+	if _, err := d.join("cpuacct"); err != nil && err != cgroups.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s *cpuacctGroup) GetStats(path string, stats *cgroups.Stats) error {
+	usage, err := getCgroupParamUint(path, "cpuacct.usage")
+	if err != nil {
+		return err
+	}
+	stats.CpuStats.Usage = usage
+
+	percpu, err := getCgroupParamUintArray(path, "cpuacct.usage_percpu")
+	if err != nil {
+		return err
+	}
+	stats.CpuStats.PerCpuUsage = percpu
+
+	kv, err := getCgroupParamKeyValue(path, "cpuacct.stat")
+	if err != nil {
+		return err
+	}
+	// cpuacct.stat reports user/system in USER_HZ clock ticks, not the
+	// nanoseconds cpuacct.usage uses; convert so CpuStats is consistently
+	// nanoseconds throughout.
+	stats.CpuStats.UsageInUsermode = kv["user"] * 1e9 / clockTicksPerSecond
+	stats.CpuStats.UsageInKernelmode = kv["system"] * 1e9 / clockTicksPerSecond
+	return nil
+}
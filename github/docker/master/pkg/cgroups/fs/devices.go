@@ -0,0 +1,52 @@
+package fs
+
+import (
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+type devicesGroup struct {
+}
+
+func (s *devicesGroup) Set(d *data) error {
+	dir, err := d.join("devices")
+	// This is done irrespective of whether the container's devices are
+	// whitelisted.
+	if err != nil {
+		return err
+	}
+
+	if !d.c.DeviceAccess {
+		if err := writeFile(dir, "devices.deny", "a"); err != nil {
+			return err
+		}
+
+		allow := []string{
+			// allow mknod for any device
+			"c *:* m",
+			"b *:* m",
+
+			// /dev/console
+			"c 5:1 rwm",
+			// /dev/tty0
+			"c 4:0 rwm",
+			// /dev/tty1
+			"c 4:1 rwm",
+			// /dev/pts/*
+			"c 136:* rwm",
+			// /dev/net/tun
+			"c 10:200 rwm",
+		}
+
+		for _, val := range allow {
+			if err := writeFile(dir, "devices.allow", val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *devicesGroup) GetStats(path string, stats *cgroups.Stats) error {
+	// devices only gates access; it has no usage counters to collect.
+	return nil
+}
@@ -0,0 +1,53 @@
+package fs
+
+import (
+	"path/filepath"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// Freezer states, written verbatim to freezer.state.
+const (
+	Frozen = "FROZEN"
+	Thawed = "THAWED"
+)
+
+type freezerGroup struct {
+}
+
+func (s *freezerGroup) Set(d *data) error {
+	// we just want to join this group even though we don't set anything,
+	// so the container can be frozen/thawed later via Freeze.
+	if _, err := d.join("freezer"); err != nil && err != cgroups.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s *freezerGroup) GetStats(path string, stats *cgroups.Stats) error {
+	// freezer carries no usage counters of its own.
+	return nil
+}
+
+// Freeze sets the freezer cgroup's state for the container described by c,
+// pausing (Frozen) or resuming (Thawed) every task in it without touching
+// the process tree.
+func Freeze(c *cgroups.Cgroup, state string) error {
+	cgroupRoot, err := cgroups.FindCgroupMountpoint("freezer")
+	if err != nil {
+		return err
+	}
+	cgroupRoot = filepath.Dir(cgroupRoot)
+
+	cgroup := c.Name
+	if c.Parent != "" {
+		cgroup = filepath.Join(c.Parent, cgroup)
+	}
+
+	d := &data{root: cgroupRoot, cgroup: cgroup, c: c}
+	dir, err := d.path("freezer")
+	if err != nil {
+		return err
+	}
+	return writeFile(dir, "freezer.state", state)
+}
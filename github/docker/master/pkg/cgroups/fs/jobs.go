@@ -0,0 +1,116 @@
+package fs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dotcloud/docker/engine"
+	"github.com/dotcloud/docker/engine/capabilities"
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// These jobs let a container's cgroup settings be driven through the
+// existing engine.Job API without a restart: pausing/unpausing freezes or
+// thaws the container's tasks, updating resources rewrites its cgroup
+// limits in place, and stats streams its resource usage.
+func init() {
+	engine.Register("container_pause", jobPause)
+	engine.Register("container_unpause", jobUnpause)
+	engine.Register("container_update_resources", jobUpdateResources)
+	// Most of what this job updates (Memory, MemorySwap,
+	// MemoryReservation, KernelMemory, MemorySwappiness) lives under the
+	// memory cgroup controller, so refuse to register it on a kernel that
+	// doesn't have one instead of failing the first time it's run.
+	engine.RegisterRequiresCapability("container_update_resources", func(c capabilities.Capabilities) bool {
+		return c.MemoryCgroup
+	})
+	engine.Register("container_stats", jobStats)
+}
+
+// cgroupFromJob builds the Cgroup describing job's target container from
+// its first argument and environment.
+func cgroupFromJob(job *engine.Job) *cgroups.Cgroup {
+	c := &cgroups.Cgroup{
+		Name:              job.Args[0],
+		CpuShares:         int64(job.GetenvInt("CpuShares")),
+		Memory:            job.GetenvInt64("Memory"),
+		MemorySwap:        job.GetenvInt64("MemorySwap"),
+		MemoryReservation: job.GetenvInt64("MemoryReservation"),
+		KernelMemory:      job.GetenvInt64("KernelMemory"),
+		BlkioWeight:       uint16(job.GetenvInt("BlkioWeight")),
+		CpusetCpus:        job.Getenv("CpusetCpus"),
+	}
+	if raw := job.Getenv("MemorySwappiness"); raw != "" {
+		swappiness := job.GetenvInt64("MemorySwappiness")
+		c.MemorySwappiness = &swappiness
+	}
+	return c
+}
+
+func jobPause(job *engine.Job) engine.Status {
+	if len(job.Args) != 1 {
+		job.Errorf("usage: %s CONTAINER", job.Name)
+		return engine.StatusErr
+	}
+	if err := Freeze(cgroupFromJob(job), Frozen); err != nil {
+		job.Error(err)
+		return engine.StatusErr
+	}
+	return engine.StatusOK
+}
+
+func jobUnpause(job *engine.Job) engine.Status {
+	if len(job.Args) != 1 {
+		job.Errorf("usage: %s CONTAINER", job.Name)
+		return engine.StatusErr
+	}
+	if err := Freeze(cgroupFromJob(job), Thawed); err != nil {
+		job.Error(err)
+		return engine.StatusErr
+	}
+	return engine.StatusOK
+}
+
+// jobStats writes one JSON-encoded cgroups.Stats per line to stdout for
+// the container named by its single argument, so docker stats-style
+// consumers can subscribe. Set the "Stream" environment variable to "0"
+// to get a single snapshot instead of polling once a second until the
+// client disconnects.
+func jobStats(job *engine.Job) engine.Status {
+	if len(job.Args) != 1 {
+		job.Errorf("usage: %s CONTAINER", job.Name)
+		return engine.StatusErr
+	}
+	c := cgroupFromJob(job)
+	stream := job.Getenv("Stream") != "0"
+
+	encoder := json.NewEncoder(job.Stdout)
+	for {
+		stats, err := GetStats(c, 0)
+		if err != nil {
+			job.Error(err)
+			return engine.StatusErr
+		}
+		if err := encoder.Encode(stats); err != nil {
+			// the client went away
+			break
+		}
+		if !stream {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return engine.StatusOK
+}
+
+func jobUpdateResources(job *engine.Job) engine.Status {
+	if len(job.Args) != 1 {
+		job.Errorf("usage: %s CONTAINER", job.Name)
+		return engine.StatusErr
+	}
+	if err := SetResources(cgroupFromJob(job)); err != nil {
+		job.Error(err)
+		return engine.StatusErr
+	}
+	return engine.StatusOK
+}
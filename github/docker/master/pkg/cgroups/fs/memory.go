@@ -0,0 +1,78 @@
+package fs
+
+import (
+	"strconv"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+type memoryGroup struct {
+}
+
+func (s *memoryGroup) Set(d *data) error {
+	dir, err := d.join("memory")
+	if err != nil {
+		if err == cgroups.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if d.c.Memory != 0 {
+		if err := writeFile(dir, "memory.limit_in_bytes", strconv.FormatInt(d.c.Memory, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.MemorySwap != 0 {
+		if err := writeFile(dir, "memory.memsw.limit_in_bytes", strconv.FormatInt(d.c.MemorySwap, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.MemoryReservation != 0 {
+		if err := writeFile(dir, "memory.soft_limit_in_bytes", strconv.FormatInt(d.c.MemoryReservation, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.KernelMemory != 0 {
+		if err := writeFile(dir, "memory.kmem.limit_in_bytes", strconv.FormatInt(d.c.KernelMemory, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.MemorySwappiness != nil {
+		if err := writeFile(dir, "memory.swappiness", strconv.FormatInt(*d.c.MemorySwappiness, 10)); err != nil {
+			return err
+		}
+	}
+	if d.c.OomKillDisable {
+		if err := writeFile(dir, "memory.oom_control", "1"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryGroup) GetStats(path string, stats *cgroups.Stats) error {
+	usage, err := getCgroupParamUint(path, "memory.usage_in_bytes")
+	if err != nil {
+		return err
+	}
+	maxUsage, err := getCgroupParamUint(path, "memory.max_usage_in_bytes")
+	if err != nil {
+		return err
+	}
+	failcnt, err := getCgroupParamUint(path, "memory.failcnt")
+	if err != nil {
+		return err
+	}
+	statMap, err := getCgroupParamKeyValue(path, "memory.stat")
+	if err != nil {
+		return err
+	}
+	stats.MemoryStats = cgroups.MemoryStats{
+		Usage:    usage,
+		MaxUsage: maxUsage,
+		Failcnt:  failcnt,
+		Stats:    statMap,
+	}
+	return nil
+}
@@ -14,3 +14,9 @@ func (s *perfEventGroup) Set(d *data) error {
 	}
 	return nil
 }
+
+func (s *perfEventGroup) GetStats(path string, stats *cgroups.Stats) error {
+	// perf_event exposes no stats of its own; joining it only lets
+	// external `perf` tooling scope to the container.
+	return nil
+}
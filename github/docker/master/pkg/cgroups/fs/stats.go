@@ -0,0 +1,75 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// GetStats gathers resource usage statistics for the cgroups described by
+// c, mirroring the path-resolution logic in Apply.
+func GetStats(c *cgroups.Cgroup, pid int) (*cgroups.Stats, error) {
+	cgroupRoot, err := cgroups.FindCgroupMountpoint("cpu")
+	if err != nil {
+		return nil, err
+	}
+	cgroupRoot = filepath.Dir(cgroupRoot)
+
+	if _, err := os.Stat(cgroupRoot); err != nil {
+		return nil, fmt.Errorf("cgroups fs not found")
+	}
+
+	cgroup := c.Name
+	if c.Parent != "" {
+		cgroup = filepath.Join(c.Parent, cgroup)
+	}
+
+	d := &data{
+		root:   cgroupRoot,
+		cgroup: cgroup,
+		c:      c,
+		pid:    pid,
+	}
+
+	stats := &cgroups.Stats{}
+	for _, sys := range subsystems {
+		path, err := d.path(sys.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			// the container was never joined to this subsystem
+			continue
+		}
+		if err := sys.sys.GetStats(path, stats); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// The per-file parsers below are thin, package-local names kept around so
+// existing call sites don't all need a rename; the actual parsing lives in
+// pkg/cgroups so pkg/cgroups/cgutil can share it instead of duplicating it.
+
+func getCgroupParamUint(path, file string) (uint64, error) {
+	return cgroups.ParseUintFile(path, file)
+}
+
+func getCgroupParamKeyValue(path, file string) (map[string]uint64, error) {
+	return cgroups.ParseKeyValueFile(path, file)
+}
+
+func getCgroupParamUintArray(path, file string) ([]uint64, error) {
+	return cgroups.ParseUintArrayFile(path, file)
+}
+
+func parseBlkioFile(path, file string) ([]cgroups.BlkioStatEntry, error) {
+	return cgroups.ParseBlkioFile(path, file)
+}
+
+func readFile(dir, file string) (string, error) {
+	return cgroups.ReadCgroupFile(dir, file)
+}
@@ -0,0 +1,41 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dotcloud/docker/engine"
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+// SetResources updates the cgroup files of an already-joined container
+// described by c, without rejoining any process, so limits can be changed
+// without a restart.
+func SetResources(c *cgroups.Cgroup) error {
+	cgroupRoot, err := cgroups.FindCgroupMountpoint("cpu")
+	if err != nil {
+		return err
+	}
+	cgroupRoot = filepath.Dir(cgroupRoot)
+
+	if _, err := os.Stat(cgroupRoot); err != nil {
+		return fmt.Errorf("cgroups fs not found")
+	}
+
+	cgroup := c.Name
+	if c.Parent != "" {
+		cgroup = filepath.Join(c.Parent, cgroup)
+	}
+
+	d := &data{root: cgroupRoot, cgroup: cgroup, c: c}
+	for _, sys := range subsystems {
+		if !engine.IsCgroupSubsystemSupported(sys.name) {
+			continue
+		}
+		if err := sys.sys.Set(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package cgroups
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ReadCgroupFile reads a single control file under dir and returns any
+// read error unchanged, including a missing file; callers check
+// os.IsNotExist to decide whether that should fail a whole Stats
+// collection or cgutil dump.
+func ReadCgroupFile(dir, file string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, file))
+	return string(data), err
+}
+
+// ParseUintFile reads a single-line numeric cgroup control file (e.g.
+// memory.usage_in_bytes). A missing file returns (0, nil): not every
+// subsystem wires up every knob.
+func ParseUintFile(dir, file string) (uint64, error) {
+	contents, err := ReadCgroupFile(dir, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(contents), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as a uint from file %s", contents, filepath.Join(dir, file))
+	}
+	return value, nil
+}
+
+// ParseKeyValueFile parses "<key> <value>\n"-formatted cgroup files such
+// as memory.stat and cpu.stat into a map.
+func ParseKeyValueFile(dir, file string) (map[string]uint64, error) {
+	out := make(map[string]uint64)
+	contents, err := ReadCgroupFile(dir, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	sc := bufio.NewScanner(strings.NewReader(contents))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		out[fields[0]] = v
+	}
+	return out, sc.Err()
+}
+
+// ParseUintArrayFile parses a whitespace-separated list of integers, as
+// found in cpuacct.usage_percpu.
+func ParseUintArrayFile(dir, file string) ([]uint64, error) {
+	contents, err := ReadCgroupFile(dir, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	fields := strings.Fields(contents)
+	out := make([]uint64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// ParseBlkioFile parses a blkio.io_*_recursive control file, whose lines
+// look like "<major>:<minor> <op> <value>", plus a trailing "Total
+// <value>" summary line that is skipped since the per-device entries
+// already carry what callers need.
+func ParseBlkioFile(dir, file string) ([]BlkioStatEntry, error) {
+	var entries []BlkioStatEntry
+	contents, err := ReadCgroupFile(dir, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	sc := bufio.NewScanner(strings.NewReader(contents))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		parts := strings.SplitN(fields[0], ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		major, err1 := strconv.ParseUint(parts[0], 10, 64)
+		minor, err2 := strconv.ParseUint(parts[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, BlkioStatEntry{Major: major, Minor: minor, Op: fields[1], Value: value})
+	}
+	return entries, sc.Err()
+}
@@ -0,0 +1,131 @@
+package cgroups
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name, contents string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseUintFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroups-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "memory.usage_in_bytes", "12345\n")
+	if v, err := ParseUintFile(dir, "memory.usage_in_bytes"); err != nil || v != 12345 {
+		t.Errorf("ParseUintFile() = %d, %v; want 12345, nil", v, err)
+	}
+
+	if v, err := ParseUintFile(dir, "missing"); err != nil || v != 0 {
+		t.Errorf("ParseUintFile(missing) = %d, %v; want 0, nil", v, err)
+	}
+
+	writeTestFile(t, dir, "bad", "not-a-number\n")
+	if _, err := ParseUintFile(dir, "bad"); err == nil {
+		t.Error("ParseUintFile(bad) = nil error, want error")
+	}
+}
+
+func TestParseKeyValueFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroups-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "memory.stat", "cache 100\nrss 200\nmalformed\nbad_value notanumber\n")
+	got, err := ParseKeyValueFile(dir, "memory.stat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]uint64{"cache": 100, "rss": 200}
+	if len(got) != len(want) || got["cache"] != want["cache"] || got["rss"] != want["rss"] {
+		t.Errorf("ParseKeyValueFile() = %v, want %v", got, want)
+	}
+
+	got, err = ParseKeyValueFile(dir, "missing")
+	if err != nil || len(got) != 0 {
+		t.Errorf("ParseKeyValueFile(missing) = %v, %v; want empty map, nil", got, err)
+	}
+}
+
+func TestParseUintArrayFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroups-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "cpuacct.usage_percpu", "10 20 30\n")
+	got, err := ParseUintArrayFile(dir, "cpuacct.usage_percpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint64{10, 20, 30}
+	if len(got) != len(want) {
+		t.Fatalf("ParseUintArrayFile() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseUintArrayFile()[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+
+	if got, err := ParseUintArrayFile(dir, "missing"); err != nil || got != nil {
+		t.Errorf("ParseUintArrayFile(missing) = %v, %v; want nil, nil", got, err)
+	}
+
+	writeTestFile(t, dir, "bad", "10 notanumber\n")
+	if _, err := ParseUintArrayFile(dir, "bad"); err == nil {
+		t.Error("ParseUintArrayFile(bad) = nil error, want error")
+	}
+}
+
+func TestParseBlkioFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroups-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "blkio.io_service_bytes_recursive",
+		"8:0 Read 1024\n"+
+			"8:0 Write 2048\n"+
+			"8:0 Total 3072\n"+
+			"malformed line\n"+
+			"9:bad Read 10\n"+
+			"Total 3072\n")
+	entries, err := ParseBlkioFile(dir, "blkio.io_service_bytes_recursive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The trailing whole-file "Total 3072" summary line has only two
+	// fields and is skipped; the per-device "8:0 Total 3072" line is a
+	// normal three-field entry and is kept.
+	want := []BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 1024},
+		{Major: 8, Minor: 0, Op: "Write", Value: 2048},
+		{Major: 8, Minor: 0, Op: "Total", Value: 3072},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("ParseBlkioFile() = %v, want %v", entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("ParseBlkioFile()[%d] = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	if entries, err := ParseBlkioFile(dir, "missing"); err != nil || len(entries) != 0 {
+		t.Errorf("ParseBlkioFile(missing) = %v, %v; want empty, nil", entries, err)
+	}
+}
@@ -0,0 +1,61 @@
+package cgroups
+
+// Stats holds the per-subsystem resource usage collected by a driver's
+// GetStats for a single container.
+type Stats struct {
+	CpuStats     CpuStats     `json:"cpu_stats,omitempty"`
+	MemoryStats  MemoryStats  `json:"memory_stats,omitempty"`
+	BlkioStats   BlkioStats   `json:"blkio_stats,omitempty"`
+	HugetlbStats HugetlbStats `json:"hugetlb_stats,omitempty"`
+}
+
+type ThrottlingData struct {
+	// Number of periods during which the container wanted to run more
+	// than its quota allowed.
+	Periods uint64 `json:"periods,omitempty"`
+	// Number of those periods during which the container was actually
+	// throttled.
+	ThrottledPeriods uint64 `json:"throttled_periods,omitempty"`
+	// Total time, in nanoseconds, that the container was throttled for.
+	ThrottledTime uint64 `json:"throttled_time,omitempty"`
+}
+
+type CpuStats struct {
+	Usage             uint64         `json:"usage"`
+	PerCpuUsage       []uint64       `json:"per_cpu_usage,omitempty"`
+	UsageInUsermode   uint64         `json:"usage_in_usermode"`
+	UsageInKernelmode uint64         `json:"usage_in_kernelmode"`
+	ThrottlingData    ThrottlingData `json:"throttling_data,omitempty"`
+}
+
+type MemoryStats struct {
+	Usage    uint64            `json:"usage"`
+	MaxUsage uint64            `json:"max_usage"`
+	Failcnt  uint64            `json:"failcnt"`
+	Stats    map[string]uint64 `json:"stats,omitempty"`
+}
+
+// BlkioStatEntry is one row of a blkio.io_*_recursive control file, keyed
+// by device major:minor and (for files that break usage down by
+// direction) operation.
+type BlkioStatEntry struct {
+	Major uint64 `json:"major"`
+	Minor uint64 `json:"minor"`
+	Op    string `json:"op,omitempty"`
+	Value uint64 `json:"value"`
+}
+
+type BlkioStats struct {
+	IoServiceBytesRecursive []BlkioStatEntry `json:"io_service_bytes_recursive,omitempty"`
+	IoServicedRecursive     []BlkioStatEntry `json:"io_serviced_recursive,omitempty"`
+	IoQueuedRecursive       []BlkioStatEntry `json:"io_queued_recursive,omitempty"`
+	IoServiceTimeRecursive  []BlkioStatEntry `json:"io_service_time_recursive,omitempty"`
+	IoWaitTimeRecursive     []BlkioStatEntry `json:"io_wait_time_recursive,omitempty"`
+	IoMergedRecursive       []BlkioStatEntry `json:"io_merged_recursive,omitempty"`
+}
+
+type HugetlbStats struct {
+	Usage    uint64 `json:"usage"`
+	MaxUsage uint64 `json:"max_usage"`
+	Failcnt  uint64 `json:"failcnt"`
+}
@@ -0,0 +1,93 @@
+// Package systemd implements the systemd cgroup driver. Instead of writing
+// cgroup control files directly, it asks systemd (over dbus, the
+// org.freedesktop.systemd1 Manager interface) to create a transient scope
+// unit per container and join the container's pid to it. This lets docker
+// coexist on systemd hosts where the kernel enforces the single-writer
+// rule and systemd would otherwise fight the raw fs driver over the
+// hierarchy.
+package systemd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-systemd/dbus"
+	godbus "github.com/godbus/dbus"
+
+	"github.com/dotcloud/docker/pkg/cgroups"
+)
+
+func init() {
+	cgroups.RegisterDriver("systemd", Apply)
+}
+
+// Apply creates a transient systemd scope unit for pid, configured from c,
+// and joins pid to it. It has the same signature as fs.Apply so the two
+// drivers are interchangeable behind cgroups.NewDriver.
+func Apply(c *cgroups.Cgroup, pid int) (cgroups.ActiveCgroup, error) {
+	conn, err := dbus.New()
+	if err != nil {
+		return nil, err
+	}
+
+	unit := unitName(c)
+	slice := "system.slice"
+	if c.Parent != "" {
+		slice = strings.Replace(c.Parent, "/", "-", -1) + ".slice"
+	}
+
+	props := []dbus.Property{
+		dbus.PropDescription("docker container " + c.Name),
+		dbus.PropSlice(slice),
+		dbus.PropPids(uint32(pid)),
+	}
+
+	if c.CpuShares != 0 {
+		props = append(props, newProperty("CPUShares", uint64(c.CpuShares)))
+	}
+	if c.Memory != 0 {
+		props = append(props, newProperty("MemoryLimit", uint64(c.Memory)))
+	}
+	// systemd requires *some* value be set before it will hand a scope its
+	// own blkio cgroup; fall back to systemd's own default weight when c
+	// doesn't specify one, the same way fs/blkio.go treats zero as unset.
+	weight := c.BlkioWeight
+	if weight == 0 {
+		weight = 1000
+	}
+	props = append(props, newProperty("BlockIOWeight", uint64(weight)))
+
+	statusChan := make(chan string, 1)
+	if _, err := conn.StartTransientUnit(unit, "replace", props, statusChan); err != nil {
+		return nil, err
+	}
+	if status := <-statusChan; status != "done" {
+		return nil, fmt.Errorf("systemd failed to start unit %s: %s", unit, status)
+	}
+
+	return &activeCgroup{unit: unit, conn: conn}, nil
+}
+
+func unitName(c *cgroups.Cgroup) string {
+	return fmt.Sprintf("docker-%s.scope", c.Name)
+}
+
+func newProperty(name string, value interface{}) dbus.Property {
+	return dbus.Property{Name: name, Value: godbus.MakeVariant(value)}
+}
+
+type activeCgroup struct {
+	unit string
+	conn *dbus.Conn
+}
+
+// Cleanup stops the transient scope unit, rather than rmdir'ing cgroup
+// directories systemd itself owns.
+func (a *activeCgroup) Cleanup() error {
+	statusChan := make(chan string, 1)
+	if _, err := a.conn.StopUnit(a.unit, "replace", statusChan); err != nil {
+		return err
+	}
+	<-statusChan
+	return nil
+}
@@ -0,0 +1,9 @@
+package utils
+
+import "io"
+
+func RandomString() string { return "deadbeef00000000" }
+
+type nopWriteCloser struct{ io.Writer }
+func (nopWriteCloser) Close() error { return nil }
+func NopWriteCloser(w io.Writer) io.WriteCloser { return nopWriteCloser{w} }